@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RedisSpec defines the desired state of Redis
+type RedisSpec struct {
+	// Tier selects the strategy config entry (e.g. "production", "development") used to size the
+	// underlying ElastiCache replication group or in-cluster StatefulSet
+	// +optional
+	Tier string `json:"tier,omitempty"`
+	// RestoreFrom is either the name of a RedisSnapshot CR in the same namespace, or a raw
+	// ElastiCache snapshot name/ARN, to restore this Redis instance from instead of provisioning empty
+	// +optional
+	RestoreFrom string `json:"restoreFrom,omitempty"`
+}
+
+// RedisStatus defines the observed state of Redis
+type RedisStatus struct {
+	Phase   croType.StatusPhase   `json:"phase,omitempty"`
+	Message croType.StatusMessage `json:"message,omitempty"`
+	// Strategy is the deployment strategy that provisioned this instance (e.g. "aws", "kubernetes")
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Redis is the Schema for the redis API
+type Redis struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisSpec   `json:"spec,omitempty"`
+	Status RedisStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisList contains a list of Redis
+type RedisList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Redis `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Redis{}, &RedisList{})
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *Redis) DeepCopyInto(out *Redis) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy creates a new Redis with the contents of the receiver
+func (in *Redis) DeepCopy() *Redis {
+	if in == nil {
+		return nil
+	}
+	out := new(Redis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *Redis) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisList) DeepCopyInto(out *RedisList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]Redis, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy creates a new RedisList with the contents of the receiver
+func (in *RedisList) DeepCopy() *RedisList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *RedisList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package v1alpha1
+
+import (
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RedisRestoreSpec defines the desired state of RedisRestore
+type RedisRestoreSpec struct {
+	// ResourceName is the name of the Redis CR to provision from the snapshot
+	ResourceName string `json:"resourceName"`
+	// SnapshotName is either the name of a RedisSnapshot CR in the same namespace,
+	// or a raw ElastiCache snapshot name to restore from
+	SnapshotName string `json:"snapshotName"`
+}
+
+// RedisRestoreStatus defines the observed state of RedisRestore
+type RedisRestoreStatus struct {
+	Phase   croType.StatusPhase   `json:"phase,omitempty"`
+	Message croType.StatusMessage `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisRestore is the Schema for the redisrestores API
+type RedisRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisRestoreSpec   `json:"spec,omitempty"`
+	Status RedisRestoreStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisRestoreList contains a list of RedisRestore
+type RedisRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisRestore{}, &RedisRestoreList{})
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisRestore) DeepCopyInto(out *RedisRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy creates a new RedisRestore with the contents of the receiver
+func (in *RedisRestore) DeepCopy() *RedisRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *RedisRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisRestoreList) DeepCopyInto(out *RedisRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]RedisRestore, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy creates a new RedisRestoreList with the contents of the receiver
+func (in *RedisRestoreList) DeepCopy() *RedisRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *RedisRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
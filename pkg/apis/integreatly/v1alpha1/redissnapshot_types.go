@@ -0,0 +1,148 @@
+package v1alpha1
+
+import (
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RedisSnapshotSpec defines the desired state of RedisSnapshot
+type RedisSnapshotSpec struct {
+	// ResourceName is the name of the Redis CR to snapshot
+	ResourceName string `json:"resourceName"`
+	// Export, if set, copies the completed snapshot into an S3 bucket once it becomes available
+	// +optional
+	Export *RedisSnapshotExport `json:"export,omitempty"`
+}
+
+// RedisSnapshotExport configures an S3 export of a completed ElastiCache snapshot
+type RedisSnapshotExport struct {
+	// BucketName is the S3 bucket the snapshot is copied into
+	BucketName string `json:"bucketName"`
+	// BucketRegion, if set and different from the cluster's region, additionally copies the snapshot
+	// into this region for cross-region DR
+	// +optional
+	BucketRegion string `json:"bucketRegion,omitempty"`
+	// KMSKeyID, if set, encrypts the exported snapshot with this KMS key instead of the bucket default
+	// +optional
+	KMSKeyID string `json:"kmsKeyId,omitempty"`
+}
+
+// RedisSnapshotStatus defines the observed state of RedisSnapshot
+type RedisSnapshotStatus struct {
+	Phase   croType.StatusPhase   `json:"phase,omitempty"`
+	Message croType.StatusMessage `json:"message,omitempty"`
+	// Export reports the progress of the S3 export requested by Spec.Export
+	// +optional
+	Export *RedisSnapshotExportStatus `json:"export,omitempty"`
+}
+
+// RedisSnapshotExportStatus reports the progress of an in-flight or completed S3 export
+type RedisSnapshotExportStatus struct {
+	// ObjectKey is the key the snapshot was (or is being) copied to within Spec.Export.BucketName
+	ObjectKey string                `json:"objectKey,omitempty"`
+	Phase     croType.StatusPhase   `json:"phase,omitempty"`
+	Message   croType.StatusMessage `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisSnapshot is the Schema for the redissnapshots API
+type RedisSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisSnapshotSpec   `json:"spec,omitempty"`
+	Status RedisSnapshotStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisSnapshotList contains a list of RedisSnapshot
+type RedisSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisSnapshot{}, &RedisSnapshotList{})
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisSnapshot) DeepCopyInto(out *RedisSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new RedisSnapshot with the contents of the receiver
+func (in *RedisSnapshot) DeepCopy() *RedisSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *RedisSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisSnapshotSpec) DeepCopyInto(out *RedisSnapshotSpec) {
+	*out = *in
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(RedisSnapshotExport)
+		**out = **in
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisSnapshotStatus) DeepCopyInto(out *RedisSnapshotStatus) {
+	*out = *in
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(RedisSnapshotExportStatus)
+		**out = **in
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisSnapshotList) DeepCopyInto(out *RedisSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]RedisSnapshot, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy creates a new RedisSnapshotList with the contents of the receiver
+func (in *RedisSnapshotList) DeepCopy() *RedisSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *RedisSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RedisSnapshotScheduleSpec defines the desired state of RedisSnapshotSchedule
+type RedisSnapshotScheduleSpec struct {
+	// ResourceName is the name of the Redis CR this schedule takes snapshots of
+	ResourceName string `json:"resourceName"`
+	// Schedule is a standard cron expression (e.g. "0 */6 * * *") describing
+	// how often a RedisSnapshot should be created
+	Schedule string `json:"schedule"`
+	// RetentionCount is the number of most recent snapshots to keep. Older
+	// snapshots beyond this count are deleted after each run
+	// +optional
+	RetentionCount int `json:"retentionCount,omitempty"`
+	// RetentionDuration is a Go duration string (e.g. "720h"). Snapshots older
+	// than this duration are deleted after each run
+	// +optional
+	RetentionDuration string `json:"retentionDuration,omitempty"`
+}
+
+// RedisSnapshotScheduleStatus defines the observed state of RedisSnapshotSchedule
+type RedisSnapshotScheduleStatus struct {
+	Phase   croType.StatusPhase   `json:"phase,omitempty"`
+	Message croType.StatusMessage `json:"message,omitempty"`
+	// LastScheduleTime is the last time a child RedisSnapshot was created
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextScheduleTime is the next time a child RedisSnapshot is due to be created
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// ActiveSnapshots lists the names of child RedisSnapshot CRs currently
+	// retained for this schedule
+	ActiveSnapshots []string `json:"activeSnapshots,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisSnapshotSchedule is the Schema for the redissnapshotschedules API
+type RedisSnapshotSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisSnapshotScheduleSpec   `json:"spec,omitempty"`
+	Status RedisSnapshotScheduleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisSnapshotScheduleList contains a list of RedisSnapshotSchedule
+type RedisSnapshotScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisSnapshotSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisSnapshotSchedule{}, &RedisSnapshotScheduleList{})
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisSnapshotSchedule) DeepCopyInto(out *RedisSnapshotSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new RedisSnapshotSchedule with the contents of the receiver
+func (in *RedisSnapshotSchedule) DeepCopy() *RedisSnapshotSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSnapshotSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *RedisSnapshotSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisSnapshotScheduleStatus) DeepCopyInto(out *RedisSnapshotScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveSnapshots != nil {
+		out.ActiveSnapshots = make([]string, len(in.ActiveSnapshots))
+		copy(out.ActiveSnapshots, in.ActiveSnapshots)
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *RedisSnapshotScheduleList) DeepCopyInto(out *RedisSnapshotScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]RedisSnapshotSchedule, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy creates a new RedisSnapshotScheduleList with the contents of the receiver
+func (in *RedisSnapshotScheduleList) DeepCopy() *RedisSnapshotScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSnapshotScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *RedisSnapshotScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
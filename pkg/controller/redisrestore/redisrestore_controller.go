@@ -0,0 +1,233 @@
+package redisrestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/sirupsen/logrus"
+
+	integreatlyv1alpha1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	croAws "github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+
+	errorUtil "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_redisrestore")
+
+// Add creates a new RedisRestore Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	logger := logrus.WithFields(logrus.Fields{"controller": "controller_redis_restore"})
+	return &ReconcileRedisRestore{
+		client:            mgr.GetClient(),
+		scheme:            mgr.GetScheme(),
+		logger:            logger,
+		ConfigManager:     croAws.NewDefaultConfigMapConfigManager(mgr.GetClient()),
+		CredentialManager: croAws.NewCredentialMinterCredentialManager(mgr.GetClient()),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("redisrestore-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RedisRestore
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.RedisRestore{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to secondary resource Redis and requeue the owner RedisRestore. The Redis CRs that
+	// Reconcile creates carry no owner reference, so their progress is picked up by RequeueAfter polling instead
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.Redis{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &integreatlyv1alpha1.RedisRestore{},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcileRedisRestore implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRedisRestore{}
+
+// ReconcileRedisRestore reconciles a RedisRestore object
+type ReconcileRedisRestore struct {
+	client            client.Client
+	scheme            *runtime.Scheme
+	logger            *logrus.Entry
+	ConfigManager     croAws.ConfigManager
+	CredentialManager croAws.CredentialManager
+}
+
+// Reconcile validates that the snapshot named by a RedisRestore is available, then orchestrates the creation of the
+// target Redis CR (with Spec.RestoreFrom pointed at the snapshot) and reports provisioning status back onto the
+// RedisRestore
+func (r *ReconcileRedisRestore) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.logger.Info("reconciling redis restore")
+	ctx := context.TODO()
+
+	instance := &integreatlyv1alpha1.RedisRestore{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if instance.Status.Phase == croType.PhaseComplete {
+		r.logger.Infof("restore for %s already complete", instance.Name)
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.validateSnapshotAvailable(ctx, instance); err != nil {
+		if updateErr := r.updatePhase(ctx, instance, croType.PhaseFailed, croType.StatusMessage(err.Error())); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+	}
+
+	// get (or create) the target redis cr, pointed at the snapshot to restore from
+	redisCr := &integreatlyv1alpha1.Redis{}
+	err = r.client.Get(ctx, types.NamespacedName{Name: instance.Spec.ResourceName, Namespace: instance.Namespace}, redisCr)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			errMsg := fmt.Sprintf("failed to get redis cr %s", instance.Spec.ResourceName)
+			if updateErr := r.updatePhase(ctx, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
+		}
+		// deliberately no OwnerReference back to instance: a RedisRestore record is a one-shot "did the restore
+		// work" artifact, and the restored Redis CR (and the live ElastiCache replication group behind it) must
+		// outlive it - owning it would mean deleting the restore record cascades into deleting the data it restored
+		redisCr = &integreatlyv1alpha1.Redis{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      instance.Spec.ResourceName,
+				Namespace: instance.Namespace,
+			},
+			Spec: integreatlyv1alpha1.RedisSpec{
+				RestoreFrom: instance.Spec.SnapshotName,
+			},
+		}
+		if err := r.client.Create(ctx, redisCr); err != nil {
+			errMsg := fmt.Sprintf("failed to create redis cr %s", instance.Spec.ResourceName)
+			if updateErr := r.updatePhase(ctx, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
+		}
+		if updateErr := r.updatePhase(ctx, instance, croType.PhaseInProgress, "provisioning redis cr from snapshot"); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+	}
+
+	if redisCr.Status.Phase != croType.PhaseComplete {
+		msg := fmt.Sprintf("waiting for redis cr %s to become available, current phase %s", redisCr.Name, redisCr.Status.Phase)
+		if updateErr := r.updatePhase(ctx, instance, croType.PhaseInProgress, croType.StatusMessage(msg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+	}
+
+	if updateErr := r.updatePhase(ctx, instance, croType.PhaseComplete, "redis restored from snapshot"); updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	return reconcile.Result{}, nil
+}
+
+// validateSnapshotAvailable confirms the ElastiCache snapshot referenced by instance has reached the "available"
+// state before a restore is attempted against it
+func (r *ReconcileRedisRestore) validateSnapshotAvailable(ctx context.Context, instance *integreatlyv1alpha1.RedisRestore) error {
+	stratCfg, err := r.ConfigManager.ReadStorageStrategy(ctx, providers.RedisResourceType, "")
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to read redis strategy config")
+	}
+	if stratCfg.Region == "" {
+		stratCfg.Region = croAws.DefaultRegion
+	}
+
+	providerCreds, err := r.CredentialManager.ReconcileProviderCredentials(ctx, instance.Namespace)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to reconcile elasticache credentials")
+	}
+
+	cacheSvc := elasticache.New(session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String(stratCfg.Region),
+		Credentials: credentials.NewStaticCredentials(providerCreds.AccessKeyID, providerCreds.SecretAccessKey, ""),
+	})))
+
+	snapshotName, err := r.resolveSnapshotName(ctx, instance)
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to resolve snapshot name %s", instance.Spec.SnapshotName)
+	}
+
+	listOutput, err := cacheSvc.DescribeSnapshots(&elasticache.DescribeSnapshotsInput{
+		SnapshotName: aws.String(snapshotName),
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to describe snapshot %s", snapshotName)
+	}
+	if len(listOutput.Snapshots) == 0 {
+		return errorUtil.Errorf("snapshot %s does not exist", snapshotName)
+	}
+	if *listOutput.Snapshots[0].SnapshotStatus != "available" {
+		return errorUtil.Errorf("snapshot %s is not yet available, current status %s", snapshotName, *listOutput.Snapshots[0].SnapshotStatus)
+	}
+	return nil
+}
+
+// resolveSnapshotName turns instance.Spec.SnapshotName into the real ElastiCache snapshot name, mirroring
+// AWSRedisProvider.resolveRestoreSnapshot: SnapshotName may either name a RedisSnapshot CR in the same namespace,
+// in which case it's resolved to the timestamped name the aws provider actually gave the ElastiCache snapshot, or
+// it may already be a raw ElastiCache snapshot name, in which case it's used as-is
+func (r *ReconcileRedisRestore) resolveSnapshotName(ctx context.Context, instance *integreatlyv1alpha1.RedisRestore) (string, error) {
+	snapshotCr := &integreatlyv1alpha1.RedisSnapshot{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: instance.Spec.SnapshotName, Namespace: instance.Namespace}, snapshotCr)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return "", errorUtil.Wrapf(err, "failed to get redis snapshot cr %s", instance.Spec.SnapshotName)
+		}
+		// not a RedisSnapshot CR, treat SnapshotName as a raw ElastiCache snapshot name
+		return instance.Spec.SnapshotName, nil
+	}
+
+	return croAws.BuildTimestampedInfraNameFromObjectCreation(ctx, r.client, snapshotCr.ObjectMeta, croAws.DefaultAwsIdentifierLength)
+}
+
+func (r *ReconcileRedisRestore) updatePhase(ctx context.Context, instance *integreatlyv1alpha1.RedisRestore, phase croType.StatusPhase, msg croType.StatusMessage) error {
+	instance.Status.Phase = phase
+	instance.Status.Message = msg
+	return r.client.Update(ctx, instance)
+}
@@ -0,0 +1,94 @@
+package redissnapshot
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	integreatlyv1alpha1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croAws "github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// enableRedisEventBridgeEnvVar opts the controller into the SNS/SQS event bridge. Left unset, the controller falls
+// back to the RequeueAfter-based polling it has always used
+const enableRedisEventBridgeEnvVar = "ENABLE_REDIS_SNS_EVENTS"
+
+// addEventBridgeWatch is a no-op unless ENABLE_REDIS_SNS_EVENTS is set, in which case it provisions the SNS/SQS
+// bridge, starts a goroutine consuming it for the lifetime of the manager, and watches the resulting event channel
+func addEventBridgeWatch(mgr manager.Manager, c controller.Controller, r *ReconcileRedisSnapshot) error {
+	if os.Getenv(enableRedisEventBridgeEnvVar) != "true" {
+		return nil
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return err
+	}
+
+	bridge, err := croAws.NewRedisEventBridge(sess, r.logger)
+	if err != nil {
+		return err
+	}
+
+	events := make(chan event.GenericEvent)
+	go bridge.Start(context.Background(), r.resolveEvent, events)
+
+	return c.Watch(&source.Channel{Source: events}, &handler.EnqueueRequestForObject{})
+}
+
+// resolveEvent maps an ElastiCache SourceIdentifier (a replication group or cache cluster id) back to the
+// RedisSnapshot and Redis CRs it belongs to, by regenerating each candidate CR's infra name and comparing - the
+// reverse of the one-way name generation in BuildInfraNameFromObject/BuildTimestampedInfraNameFromObjectCreation
+func (r *ReconcileRedisSnapshot) resolveEvent(ctx context.Context, sourceIdentifier string) ([]event.GenericEvent, error) {
+	var matches []event.GenericEvent
+
+	snapshotList := &integreatlyv1alpha1.RedisSnapshotList{}
+	if err := r.client.List(ctx, &client.ListOptions{}, snapshotList); err != nil {
+		return nil, err
+	}
+	for i := range snapshotList.Items {
+		snapshotCr := &snapshotList.Items[i]
+		name, err := croAws.BuildTimestampedInfraNameFromObjectCreation(ctx, r.client, snapshotCr.ObjectMeta, croAws.DefaultAwsIdentifierLength)
+		if err != nil {
+			continue
+		}
+		if name == sourceIdentifier {
+			matches = append(matches, event.GenericEvent{Meta: snapshotCr, Object: snapshotCr})
+		}
+	}
+
+	// a Redis CR event (e.g. its replication group becoming available) can't be turned into a GenericEvent for
+	// the Redis CR itself - ReconcileRedisSnapshot.Reconcile only ever looks up a RedisSnapshot by the incoming
+	// request's name, so a request carrying a Redis CR's name would almost certainly resolve NotFound. Instead,
+	// once the Redis CR owning sourceIdentifier is found, emit events for the RedisSnapshot CRs that target it
+	redisList := &integreatlyv1alpha1.RedisList{}
+	if err := r.client.List(ctx, &client.ListOptions{}, redisList); err != nil {
+		return nil, err
+	}
+	for i := range redisList.Items {
+		redisCr := &redisList.Items[i]
+		name, err := croAws.BuildInfraNameFromObject(ctx, r.client, redisCr.ObjectMeta, croAws.DefaultAwsIdentifierLength)
+		if err != nil {
+			continue
+		}
+		if name != sourceIdentifier {
+			continue
+		}
+		for j := range snapshotList.Items {
+			snapshotCr := &snapshotList.Items[j]
+			if snapshotCr.Namespace == redisCr.Namespace && snapshotCr.Spec.ResourceName == redisCr.Name {
+				matches = append(matches, event.GenericEvent{Meta: snapshotCr, Object: snapshotCr})
+			}
+		}
+	}
+
+	return matches, nil
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/aws/aws-sdk-go/service/s3"
 	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
 	croAws "github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	croK8s "github.com/integr8ly/cloud-resource-operator/pkg/providers/kubernetes"
 	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,10 +36,15 @@ import (
 
 var log = logf.Log.WithName("controller_redissnapshot")
 
+// snapshotFinalizer is added to every RedisSnapshot so deletion always goes through reconcileDelete, giving the
+// controller a chance to clean up the remote AWS/in-cluster snapshot first
+const snapshotFinalizer = "redissnapshot.aws.cloud-resources.integreatly.org/finalizer"
+
 // Add creates a new RedisSnapshot Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	r := newReconciler(mgr).(*ReconcileRedisSnapshot)
+	return add(mgr, r)
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -51,7 +60,7 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+func add(mgr manager.Manager, r *ReconcileRedisSnapshot) error {
 	// Create a new controller
 	c, err := controller.New("redissnapshot-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
@@ -73,6 +82,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Optionally watch an SNS/SQS event bridge so snapshot/replication-group state changes are reconciled
+	// as soon as AWS reports them, rather than waiting on the 60s RequeueAfter polling done by Reconcile
+	if err := addEventBridgeWatch(mgr, c, r); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -112,31 +127,77 @@ func (r *ReconcileRedisSnapshot) Reconcile(request reconcile.Request) (reconcile
 		return reconcile.Result{}, err
 	}
 
-	// check status, if complete return
-	if instance.Status.Phase == croType.PhaseComplete {
-		r.logger.Infof("snapshot for %s exists", instance.Name)
-		return reconcile.Result{}, nil
-	}
-
 	// get redis cr
 	redisCr := &integreatlyv1alpha1.Redis{}
 	err = r.client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.ResourceName, Namespace: instance.Namespace}, redisCr)
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		errMsg := fmt.Sprintf("failed to get redis cr : %s", err.Error())
 		if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
 			return reconcile.Result{}, updateErr
 		}
 	}
 
-	// check redis cr deployment type is aws
-	if redisCr.Status.Strategy != providers.AWSDeploymentStrategy {
+	// handle deletion before anything else, so a snapshot stuck failing to create can still be torn down
+	if instance.GetDeletionTimestamp() != nil {
+		return r.reconcileDelete(ctx, instance, redisCr)
+	}
+
+	// only write when the finalizer is actually missing - this controller watches its own primary resource
+	// via EnqueueRequestForObject, so an unconditional Update here would re-trigger this same reconcile
+	// forever, including on an already-PhaseComplete snapshot
+	if !hasFinalizer(instance, snapshotFinalizer) {
+		resources.AddFinalizer(&instance.ObjectMeta, snapshotFinalizer)
+		if updateErr := r.client.Update(ctx, instance); updateErr != nil {
+			return reconcile.Result{}, errorUtil.Wrapf(updateErr, "failed to add finalizer to instance")
+		}
+	}
+
+	// check status, if complete return - unless an aws snapshot still has an unfinished s3 export, which
+	// reconcileAWSSnapshot keeps driving through reconcileSnapshotExport
+	exportPending := instance.Spec.Export != nil && redisCr.Status.Strategy == providers.AWSDeploymentStrategy &&
+		(instance.Status.Export == nil || instance.Status.Export.Phase != croType.PhaseComplete)
+	if instance.Status.Phase == croType.PhaseComplete && !exportPending {
+		r.logger.Infof("snapshot for %s exists", instance.Name)
+		return reconcile.Result{}, nil
+	}
+
+	switch redisCr.Status.Strategy {
+	case providers.AWSDeploymentStrategy:
+		return r.reconcileAWSSnapshot(ctx, instance, redisCr)
+	case providers.KubernetesDeploymentStrategy:
+		return r.reconcileKubernetesSnapshot(ctx, instance, redisCr)
+	default:
 		errMsg := "none supported deployment strategy"
 		if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
 			return reconcile.Result{}, updateErr
 		}
 		return reconcile.Result{}, errorUtil.New(errMsg)
 	}
+}
 
+// reconcileKubernetesSnapshot drives a BGSAVE-based snapshot of an in-cluster Redis StatefulSet, mirroring the
+// polling/phase-reporting shape of reconcileAWSSnapshot
+func (r *ReconcileRedisSnapshot) reconcileKubernetesSnapshot(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshot, redisCr *integreatlyv1alpha1.Redis) (reconcile.Result, error) {
+	phase, err := croK8s.CreateRedisSnapshot(ctx, r.client, r.logger, redisCr, instance)
+	if err != nil {
+		if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusMessage(err.Error())); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+	}
+
+	msg := fmt.Sprintf("current snapshot status : %s", phase)
+	if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, phase, croType.StatusMessage(msg)); updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	if phase == croType.PhaseComplete {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+}
+
+// reconcileAWSSnapshot drives an ElastiCache snapshot of an AWS-provisioned Redis replication group
+func (r *ReconcileRedisSnapshot) reconcileAWSSnapshot(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshot, redisCr *integreatlyv1alpha1.Redis) (reconcile.Result, error) {
 	// get resource region
 	stratCfg, err := r.ConfigManager.ReadStorageStrategy(ctx, providers.RedisResourceType, redisCr.Spec.Tier)
 	if err != nil {
@@ -211,22 +272,31 @@ func (r *ReconcileRedisSnapshot) Reconcile(request reconcile.Request) (reconcile
 		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, errorUtil.Wrap(err, "failed to get cluster name")
 	}
 
-	// find primary cache node
-	cacheName := ""
-	for _, i := range cacheOutput.ReplicationGroups[0].NodeGroups[0].NodeGroupMembers {
-		if *i.CurrentRole == "primary" {
-			cacheName = *i.CacheClusterId
-			break
+	// find the primary cache node in each node group/shard. A classic (non-sharded) replication
+	// group has exactly one node group; a cluster-mode-enabled one has up to 90
+	nodeGroups := cacheOutput.ReplicationGroups[0].NodeGroups
+	primaryCacheIds := make([]string, 0, len(nodeGroups))
+	for _, ng := range nodeGroups {
+		for _, i := range ng.NodeGroupMembers {
+			if *i.CurrentRole == "primary" {
+				primaryCacheIds = append(primaryCacheIds, *i.CacheClusterId)
+				break
+			}
 		}
 	}
 
-	// create snapshot of primary cache node
+	// create snapshot of the replication group. For a single node group, CreateSnapshot is called
+	// against its primary cache cluster; for cluster-mode-enabled groups with multiple node groups,
+	// CreateSnapshot is called against the replication group itself so every shard is captured
 	if foundSnapshot == nil {
 		r.logger.Info("creating elasticache snapshot")
-		if _, err = cacheSvc.CreateSnapshot(&elasticache.CreateSnapshotInput{
-			CacheClusterId: aws.String(cacheName),
-			SnapshotName:   aws.String(snapshotName),
-		}); err != nil {
+		snapshotInput := &elasticache.CreateSnapshotInput{SnapshotName: aws.String(snapshotName)}
+		if len(nodeGroups) > 1 {
+			snapshotInput.ReplicationGroupId = aws.String(clusterName)
+		} else if len(primaryCacheIds) > 0 {
+			snapshotInput.CacheClusterId = aws.String(primaryCacheIds[0])
+		}
+		if _, err = cacheSvc.CreateSnapshot(snapshotInput); err != nil {
 			errMsg := fmt.Sprintf("error creating elasticache snapshot %s", err)
 			return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
 		}
@@ -242,6 +312,10 @@ func (r *ReconcileRedisSnapshot) Reconcile(request reconcile.Request) (reconcile
 		if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseComplete, "snapshot created"); updateErr != nil {
 			return reconcile.Result{}, err
 		}
+		if instance.Spec.Export != nil {
+			return r.reconcileSnapshotExport(ctx, instance, cacheSvc, providerCreds, stratCfg.Region, snapshotName)
+		}
+		return reconcile.Result{}, nil
 	}
 
 	msg := fmt.Sprintf("current snapshot status :  %s", *foundSnapshot.SnapshotStatus)
@@ -251,3 +325,260 @@ func (r *ReconcileRedisSnapshot) Reconcile(request reconcile.Request) (reconcile
 	}
 	return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
 }
+
+// elastiCacheExportCanonicalIDs are AWS's published canonical user IDs for the ElastiCache service account that
+// performs S3 snapshot exports, keyed by region. A bucket must grant this principal READ/WRITE before CopySnapshot
+// can write the RDB file into it. See the "Exporting a Backup" section of the ElastiCache user guide
+var elastiCacheExportCanonicalIDs = map[string]string{
+	"us-east-1": "540804c33a284a299d2547575ce1010f2312ef3da9b3a053c8bc45bf233e4353",
+	"us-east-2": "591167645176bfb7a787f6e0ea41dea91a2713eba2a4d80fe39f03d5eaa1b5b9",
+	"us-west-1": "2e931bf306027cd9a1cadd5353022c6a8b49c7c3fab2eb5da2a3e6e34e9a3bf5",
+	"us-west-2": "f8a50a2ea25f43d2c1280d46cbdd1e6b75a0f42fdd08cf0a09b2c6d2b6d74f8c",
+	"eu-west-1": "462be301a43de8daddf5f1eb84c22c2315d28297cd54c3d3f1e1f8a1a4ec1ba0",
+}
+
+// reconcileSnapshotExport copies a completed ElastiCache snapshot into instance.Spec.Export.BucketName so it can
+// seed a restore in another region or be archived to Glacier. It grants the ElastiCache service account access to
+// the bucket as a preflight step, then polls CopySnapshot's own available/copying/failed status the same way
+// reconcileAWSSnapshot polls snapshot creation, recording the resulting S3 object key on instance.Status.Export
+func (r *ReconcileRedisSnapshot) reconcileSnapshotExport(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshot, cacheSvc elasticacheiface.ElastiCacheAPI, providerCreds *croAws.AWSCredentials, clusterRegion, snapshotName string) (reconcile.Result, error) {
+	export := instance.Spec.Export
+
+	if err := r.grantElastiCacheBucketAccess(export.BucketName, clusterRegion, providerCreds); err != nil {
+		errMsg := fmt.Sprintf("failed to grant elasticache access to export bucket %s", export.BucketName)
+		if updateErr := r.updateExportStatus(ctx, instance, "", croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
+	}
+
+	objectKey := fmt.Sprintf("%s.rdb", snapshotName)
+	if instance.Status.Export == nil || instance.Status.Export.ObjectKey == "" {
+		if _, err := cacheSvc.CopySnapshot(&elasticache.CopySnapshotInput{
+			SourceSnapshotName: aws.String(snapshotName),
+			TargetSnapshotName: aws.String(snapshotName),
+			TargetBucket:       aws.String(export.BucketName),
+			KmsKeyId:           stringOrNil(export.KMSKeyID),
+		}); err != nil {
+			errMsg := fmt.Sprintf("failed to copy snapshot %s to bucket %s", snapshotName, export.BucketName)
+			if updateErr := r.updateExportStatus(ctx, instance, "", croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
+		}
+		// record objectKey now, not just the in-progress phase, so the next reconcile falls through to the
+		// DescribeSnapshots poll below instead of re-issuing CopySnapshot against the same source/target names
+		if updateErr := r.updateExportStatus(ctx, instance, objectKey, croType.PhaseInProgress, "copying snapshot to s3"); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+	}
+
+	describeOutput, err := cacheSvc.DescribeSnapshots(&elasticache.DescribeSnapshotsInput{SnapshotName: aws.String(snapshotName)})
+	if err != nil {
+		return reconcile.Result{}, errorUtil.Wrap(err, "failed to describe exported snapshot")
+	}
+	var exported *elasticache.Snapshot
+	for _, s := range describeOutput.Snapshots {
+		if aws.StringValue(s.SnapshotName) == snapshotName {
+			exported = s
+			break
+		}
+	}
+	if exported == nil {
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+	}
+
+	if aws.StringValue(exported.SnapshotStatus) != "available" {
+		msg := fmt.Sprintf("copying snapshot to s3, status %s", aws.StringValue(exported.SnapshotStatus))
+		if updateErr := r.updateExportStatus(ctx, instance, objectKey, croType.PhaseInProgress, croType.StatusMessage(msg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 60}, nil
+	}
+
+	// the primary export is available - optionally fan it out to a second region for cross-region DR
+	if export.BucketRegion != "" && export.BucketRegion != clusterRegion {
+		if err := r.grantElastiCacheBucketAccess(export.BucketName, export.BucketRegion, providerCreds); err != nil {
+			errMsg := fmt.Sprintf("failed to grant elasticache access to dr export bucket in %s", export.BucketRegion)
+			if updateErr := r.updateExportStatus(ctx, instance, objectKey, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
+		}
+
+		drSvc := elasticache.New(session.Must(session.NewSession(&aws.Config{
+			Region:      aws.String(export.BucketRegion),
+			Credentials: credentials.NewStaticCredentials(providerCreds.AccessKeyID, providerCreds.SecretAccessKey, ""),
+		})))
+		if _, err := drSvc.CopySnapshot(&elasticache.CopySnapshotInput{
+			SourceSnapshotName: aws.String(snapshotName),
+			TargetSnapshotName: aws.String(snapshotName),
+			TargetBucket:       aws.String(export.BucketName),
+			KmsKeyId:           stringOrNil(export.KMSKeyID),
+		}); err != nil {
+			errMsg := fmt.Sprintf("failed to copy snapshot %s to dr region %s", snapshotName, export.BucketRegion)
+			if updateErr := r.updateExportStatus(ctx, instance, objectKey, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
+		}
+	}
+
+	if updateErr := r.updateExportStatus(ctx, instance, objectKey, croType.PhaseComplete, "snapshot exported to s3"); updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	return reconcile.Result{}, nil
+}
+
+// updateExportStatus records the export's current s3 object key and copy phase on instance.Status.Export
+func (r *ReconcileRedisSnapshot) updateExportStatus(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshot, objectKey string, phase croType.StatusPhase, msg croType.StatusMessage) error {
+	instance.Status.Export = &integreatlyv1alpha1.RedisSnapshotExportStatus{
+		ObjectKey: objectKey,
+		Phase:     phase,
+		Message:   msg,
+	}
+	return r.client.Update(ctx, instance)
+}
+
+// grantElastiCacheBucketAccess is the preflight step CopySnapshot requires: it adds READ/WRITE grants for region's
+// ElastiCache service account to bucketName's ACL, alongside whatever grants already exist
+func (r *ReconcileRedisSnapshot) grantElastiCacheBucketAccess(bucketName, region string, providerCreds *croAws.AWSCredentials) error {
+	if region == "" {
+		region = croAws.DefaultRegion
+	}
+	canonicalID, ok := elastiCacheExportCanonicalIDs[region]
+	if !ok {
+		canonicalID = elastiCacheExportCanonicalIDs[croAws.DefaultRegion]
+	}
+
+	s3Svc := s3.New(session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewStaticCredentials(providerCreds.AccessKeyID, providerCreds.SecretAccessKey, ""),
+	})))
+
+	acl, err := s3Svc.GetBucketAcl(&s3.GetBucketAclInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to get acl for export bucket %s", bucketName)
+	}
+
+	grants := acl.Grants
+	changed := false
+	for _, permission := range []string{s3.PermissionRead, s3.PermissionWrite} {
+		if hasGrant(grants, canonicalID, permission) {
+			continue
+		}
+		grants = append(grants, &s3.Grant{
+			Grantee:    &s3.Grantee{Type: aws.String(s3.TypeCanonicalUser), ID: aws.String(canonicalID)},
+			Permission: aws.String(permission),
+		})
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if _, err := s3Svc.PutBucketAcl(&s3.PutBucketAclInput{
+		Bucket:              aws.String(bucketName),
+		AccessControlPolicy: &s3.AccessControlPolicy{Owner: acl.Owner, Grants: grants},
+	}); err != nil {
+		return errorUtil.Wrapf(err, "failed to put acl for export bucket %s", bucketName)
+	}
+	return nil
+}
+
+// hasGrant reports whether grants already contains a grant for the given canonical user ID and permission
+func hasGrant(grants []*s3.Grant, canonicalID, permission string) bool {
+	for _, g := range grants {
+		if g.Grantee != nil && aws.StringValue(g.Grantee.Type) == s3.TypeCanonicalUser &&
+			aws.StringValue(g.Grantee.ID) == canonicalID && aws.StringValue(g.Permission) == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// stringOrNil returns nil for an empty string so optional *string SDK fields are left unset rather than pointing
+// at an empty value
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
+
+// reconcileDelete tears down the remote snapshot backing instance (the ElastiCache snapshot, or for an in-cluster
+// Redis the bgsave Job removed by croK8s.DeleteRedisSnapshot) before removing its finalizer. If instance carries resources.DestroyAnnotation, the remote snapshot is assumed unreachable or
+// already gone and deletion skips straight to the finalizer removal
+func (r *ReconcileRedisSnapshot) reconcileDelete(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshot, redisCr *integreatlyv1alpha1.Redis) (reconcile.Result, error) {
+	if resources.IsDestroySkipRemoteDeleteSet(instance) {
+		r.logger.Infof("destroy annotation set on %s, skipping remote snapshot deletion", instance.Name)
+		return r.removeSnapshotFinalizer(ctx, instance)
+	}
+
+	switch redisCr.Status.Strategy {
+	case providers.KubernetesDeploymentStrategy:
+		if err := croK8s.DeleteRedisSnapshot(ctx, r.client, instance); err != nil {
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to delete kubernetes redis snapshot")
+		}
+	default:
+		stratCfg, err := r.ConfigManager.ReadStorageStrategy(ctx, providers.RedisResourceType, redisCr.Spec.Tier)
+		if err != nil {
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to read redis strategy config")
+		}
+		if stratCfg.Region == "" {
+			stratCfg.Region = croAws.DefaultRegion
+		}
+
+		providerCreds, err := r.CredentialManager.ReconcileProviderCredentials(ctx, redisCr.Namespace)
+		if err != nil {
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to reconcile elasticache credentials")
+		}
+
+		cacheSvc := elasticache.New(session.Must(session.NewSession(&aws.Config{
+			Region:      aws.String(stratCfg.Region),
+			Credentials: credentials.NewStaticCredentials(providerCreds.AccessKeyID, providerCreds.SecretAccessKey, ""),
+		})))
+
+		if err := r.deleteAWSSnapshot(ctx, cacheSvc, instance); err != nil {
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to delete aws elasticache snapshot")
+		}
+	}
+
+	return r.removeSnapshotFinalizer(ctx, instance)
+}
+
+// hasFinalizer reports whether finalizer is already present on instance
+func hasFinalizer(instance *integreatlyv1alpha1.RedisSnapshot, finalizer string) bool {
+	for _, f := range instance.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// removeSnapshotFinalizer strips snapshotFinalizer from instance, allowing its deletion to complete
+func (r *ReconcileRedisSnapshot) removeSnapshotFinalizer(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshot) (reconcile.Result, error) {
+	resources.RemoveFinalizer(&instance.ObjectMeta, snapshotFinalizer)
+	if err := r.client.Update(ctx, instance); err != nil {
+		return reconcile.Result{}, errorUtil.Wrapf(err, "failed to remove finalizer from instance")
+	}
+	return reconcile.Result{}, nil
+}
+
+// deleteAWSSnapshot removes the ElastiCache snapshot backing instance, tolerating it already being gone
+func (r *ReconcileRedisSnapshot) deleteAWSSnapshot(ctx context.Context, cacheSvc elasticacheiface.ElastiCacheAPI, instance *integreatlyv1alpha1.RedisSnapshot) error {
+	snapshotName, err := croAws.BuildTimestampedInfraNameFromObjectCreation(ctx, r.client, instance.ObjectMeta, croAws.DefaultAwsIdentifierLength)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to generate snapshot name")
+	}
+
+	if _, err = cacheSvc.DeleteSnapshot(&elasticache.DeleteSnapshotInput{SnapshotName: aws.String(snapshotName)}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elasticache.ErrCodeSnapshotNotFoundFault {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package redissnapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	integreatlyv1alpha1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	croAws "github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// succeedingConfigManager always returns a fixed strategy config, so tests can exercise the CredentialManager/
+// cacheSvc path without depending on a real cloud-resources-aws-strategies configmap existing
+type succeedingConfigManager struct {
+	croAws.ConfigManager
+}
+
+func (succeedingConfigManager) ReadStorageStrategy(ctx context.Context, rt providers.ResourceType, tier string) (*croAws.StrategyConfig, error) {
+	return &croAws.StrategyConfig{Region: "eu-west-1"}, nil
+}
+
+// failingCredentialManager always fails ReconcileProviderCredentials, standing in for invalid/unreachable
+// provider credentials. Embedding the interface means it satisfies CredentialManager without needing its
+// full method set
+type failingCredentialManager struct {
+	croAws.CredentialManager
+}
+
+func (failingCredentialManager) ReconcileProviderCredentials(ctx context.Context, ns string) (*croAws.AWSCredentials, error) {
+	return nil, awserr.New("InvalidClientTokenId", "the security token included in the request is invalid", nil)
+}
+
+// explodingConfigManager fails the test if it's ever called - proves the destroy-annotation path never reaches
+// out to AWS config/credentials at all
+type explodingConfigManager struct {
+	croAws.ConfigManager
+	t *testing.T
+}
+
+func (e explodingConfigManager) ReadStorageStrategy(ctx context.Context, rt providers.ResourceType, tier string) (*croAws.StrategyConfig, error) {
+	e.t.Fatal("ReadStorageStrategy should not be called when the destroy annotation is set")
+	return nil, nil
+}
+
+// fakeElastiCacheClient stubs only the ElastiCache calls deleteAWSSnapshot makes; embedding the interface
+// satisfies the rest of elasticacheiface.ElastiCacheAPI without having to implement it in full
+type fakeElastiCacheClient struct {
+	elasticacheiface.ElastiCacheAPI
+	deleteSnapshotFn func(*elasticache.DeleteSnapshotInput) (*elasticache.DeleteSnapshotOutput, error)
+}
+
+func (f *fakeElastiCacheClient) DeleteSnapshot(in *elasticache.DeleteSnapshotInput) (*elasticache.DeleteSnapshotOutput, error) {
+	return f.deleteSnapshotFn(in)
+}
+
+func testSnapshotCr(destroyAnnotation bool) *integreatlyv1alpha1.RedisSnapshot {
+	s := &integreatlyv1alpha1.RedisSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-snapshot",
+			Namespace:  "test-ns",
+			Finalizers: []string{snapshotFinalizer},
+		},
+		Spec: integreatlyv1alpha1.RedisSnapshotSpec{
+			ResourceName: "test-redis",
+		},
+	}
+	if destroyAnnotation {
+		s.SetAnnotations(map[string]string{resources.DestroyAnnotation: "true"})
+	}
+	return s
+}
+
+func fakeSnapshotClient(objs ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := integreatlyv1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return fake.NewFakeClientWithScheme(scheme, objs...)
+}
+
+func hasSnapshotFinalizer(s *integreatlyv1alpha1.RedisSnapshot) bool {
+	for _, f := range s.Finalizers {
+		if f == snapshotFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReconcileDelete_DestroyAnnotationSkipsRemoteDelete(t *testing.T) {
+	instance := testSnapshotCr(true)
+	redisCr := &integreatlyv1alpha1.Redis{}
+	r := &ReconcileRedisSnapshot{
+		client:        fakeSnapshotClient(instance),
+		logger:        logrus.WithField("test", "true"),
+		ConfigManager: explodingConfigManager{t: t},
+	}
+
+	if _, err := r.reconcileDelete(context.TODO(), instance, redisCr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasSnapshotFinalizer(instance) {
+		t.Error("expected finalizer to be removed when destroy annotation is set")
+	}
+}
+
+func TestReconcileDelete_CredentialsInvalid(t *testing.T) {
+	for _, destroyAnnotation := range []bool{true, false} {
+		instance := testSnapshotCr(destroyAnnotation)
+		redisCr := &integreatlyv1alpha1.Redis{}
+		r := &ReconcileRedisSnapshot{
+			client:            fakeSnapshotClient(instance),
+			logger:            logrus.WithField("test", "true"),
+			ConfigManager:     succeedingConfigManager{},
+			CredentialManager: failingCredentialManager{},
+		}
+
+		_, err := r.reconcileDelete(context.TODO(), instance, redisCr)
+		if destroyAnnotation {
+			// the destroy annotation short-circuits before credentials are ever reconciled
+			if err != nil {
+				t.Errorf("destroy annotation set: unexpected error: %v", err)
+			}
+			if hasSnapshotFinalizer(instance) {
+				t.Error("destroy annotation set: expected finalizer to be removed")
+			}
+			continue
+		}
+		if err == nil {
+			t.Error("destroy annotation unset: expected error when credentials are invalid")
+		}
+		if !hasSnapshotFinalizer(instance) {
+			t.Error("destroy annotation unset: finalizer should remain while credentials are invalid")
+		}
+	}
+}
+
+func TestDeleteAWSSnapshot_SnapshotMissing(t *testing.T) {
+	instance := testSnapshotCr(false)
+	r := &ReconcileRedisSnapshot{client: fakeSnapshotClient(instance)}
+	cacheSvc := &fakeElastiCacheClient{
+		deleteSnapshotFn: func(*elasticache.DeleteSnapshotInput) (*elasticache.DeleteSnapshotOutput, error) {
+			return nil, awserr.New(elasticache.ErrCodeSnapshotNotFoundFault, "snapshot does not exist", nil)
+		},
+	}
+
+	if err := r.deleteAWSSnapshot(context.TODO(), cacheSvc, instance); err != nil {
+		t.Fatalf("expected a missing snapshot to be treated as already deleted, got: %v", err)
+	}
+}
+
+func TestDeleteAWSSnapshot_AccessDenied(t *testing.T) {
+	instance := testSnapshotCr(false)
+	r := &ReconcileRedisSnapshot{client: fakeSnapshotClient(instance)}
+	cacheSvc := &fakeElastiCacheClient{
+		deleteSnapshotFn: func(*elasticache.DeleteSnapshotInput) (*elasticache.DeleteSnapshotOutput, error) {
+			return nil, awserr.New("AccessDenied", "user is not authorized to perform elasticache:DeleteSnapshot", nil)
+		},
+	}
+
+	if err := r.deleteAWSSnapshot(context.TODO(), cacheSvc, instance); err == nil {
+		t.Fatal("expected an error when aws denies the delete")
+	}
+}
@@ -0,0 +1,229 @@
+package redissnapshotschedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+
+	integreatlyv1alpha1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+
+	errorUtil "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_redissnapshotschedule")
+
+// defaultRequeueTime is used whenever we don't yet know a more precise
+// next-fire time to requeue on (e.g. a parse error)
+const defaultRequeueTime = time.Minute
+
+// Add creates a new RedisSnapshotSchedule Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	logger := logrus.WithFields(logrus.Fields{"controller": "controller_redis_snapshot_schedule"})
+	return &ReconcileRedisSnapshotSchedule{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		logger: logger,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("redissnapshotschedule-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RedisSnapshotSchedule
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.RedisSnapshotSchedule{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to secondary resource RedisSnapshot and requeue the owner RedisSnapshotSchedule
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.RedisSnapshot{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &integreatlyv1alpha1.RedisSnapshotSchedule{},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcileRedisSnapshotSchedule implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRedisSnapshotSchedule{}
+
+// ReconcileRedisSnapshotSchedule reconciles a RedisSnapshotSchedule object
+type ReconcileRedisSnapshotSchedule struct {
+	client client.Client
+	scheme *runtime.Scheme
+	logger *logrus.Entry
+}
+
+// Reconcile reads the state of a RedisSnapshotSchedule, creates a child RedisSnapshot once the schedule is due, and
+// prunes older child snapshots according to the retention settings on the schedule
+func (r *ReconcileRedisSnapshotSchedule) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.logger.Info("reconciling redis snapshot schedule")
+	ctx := context.TODO()
+
+	instance := &integreatlyv1alpha1.RedisSnapshotSchedule{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected via the ownerReference.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	schedule, err := cron.ParseStandard(instance.Spec.Schedule)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to parse cron schedule %q", instance.Spec.Schedule)
+		if updateErr := r.updatePhase(ctx, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		r.logger.Error(errorUtil.Wrap(err, errMsg))
+		return reconcile.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+
+	lastRun := instance.CreationTimestamp.Time
+	if instance.Status.LastScheduleTime != nil {
+		lastRun = instance.Status.LastScheduleTime.Time
+	}
+	nextRun := schedule.Next(lastRun)
+
+	now := time.Now()
+	if now.Before(nextRun) {
+		// only write (and so only requeue via our own EnqueueRequestForObject watch) if the computed next-run
+		// time actually changed - otherwise every "not yet due" reconcile re-triggers itself immediately instead
+		// of sleeping until nextRun as RequeueAfter intends
+		if instance.Status.NextScheduleTime == nil || !instance.Status.NextScheduleTime.Time.Equal(nextRun) {
+			instance.Status.NextScheduleTime = &metav1.Time{Time: nextRun}
+			if updateErr := r.client.Update(ctx, instance); updateErr != nil {
+				return reconcile.Result{}, errorUtil.Wrap(updateErr, "failed to update next schedule time")
+			}
+		}
+		return reconcile.Result{RequeueAfter: nextRun.Sub(now)}, nil
+	}
+
+	// the schedule is due, create a child snapshot
+	snapshot := &integreatlyv1alpha1.RedisSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: instance.Name + "-",
+			Namespace:    instance.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(instance, integreatlyv1alpha1.SchemeGroupVersion.WithKind("RedisSnapshotSchedule")),
+			},
+		},
+		Spec: integreatlyv1alpha1.RedisSnapshotSpec{
+			ResourceName: instance.Spec.ResourceName,
+		},
+	}
+	if err := r.client.Create(ctx, snapshot); err != nil {
+		errMsg := "failed to create child redis snapshot"
+		if updateErr := r.updatePhase(ctx, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{}, errorUtil.Wrap(err, errMsg)
+	}
+
+	// record that this fire happened, and compute the following one, before attempting pruning - so a pruning
+	// failure (a malformed RetentionDuration, a transient list/delete error) can't leave LastScheduleTime stale
+	// and cause every retry to recompute the same still-due nextRun and create yet another child snapshot
+	instance.Status.LastScheduleTime = &metav1.Time{Time: now}
+	instance.Status.NextScheduleTime = &metav1.Time{Time: schedule.Next(now)}
+	if err := r.client.Update(ctx, instance); err != nil {
+		return reconcile.Result{}, errorUtil.Wrap(err, "failed to update redis snapshot schedule status")
+	}
+
+	active, err := r.pruneSnapshots(ctx, instance)
+	if err != nil {
+		return reconcile.Result{}, errorUtil.Wrap(err, "failed to prune old redis snapshots")
+	}
+
+	instance.Status.Phase = croType.PhaseComplete
+	instance.Status.Message = "scheduled snapshot created"
+	instance.Status.ActiveSnapshots = active
+	if err := r.client.Update(ctx, instance); err != nil {
+		return reconcile.Result{}, errorUtil.Wrap(err, "failed to update redis snapshot schedule status")
+	}
+
+	return reconcile.Result{RequeueAfter: instance.Status.NextScheduleTime.Sub(now)}, nil
+}
+
+// pruneSnapshots lists the child snapshots owned by instance, deletes those beyond RetentionCount and/or older than
+// RetentionDuration, and returns the names of the snapshots that remain
+func (r *ReconcileRedisSnapshotSchedule) pruneSnapshots(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshotSchedule) ([]string, error) {
+	list := &integreatlyv1alpha1.RedisSnapshotList{}
+	if err := r.client.List(ctx, &client.ListOptions{Namespace: instance.Namespace}, list); err != nil {
+		return nil, err
+	}
+
+	var owned []integreatlyv1alpha1.RedisSnapshot
+	for _, s := range list.Items {
+		for _, ref := range s.GetOwnerReferences() {
+			if ref.UID == instance.GetUID() {
+				owned = append(owned, s)
+				break
+			}
+		}
+	}
+
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].CreationTimestamp.After(owned[j].CreationTimestamp.Time)
+	})
+
+	var retentionCutoff time.Time
+	if instance.Spec.RetentionDuration != "" {
+		d, err := time.ParseDuration(instance.Spec.RetentionDuration)
+		if err != nil {
+			return nil, errorUtil.Wrapf(err, "failed to parse retention duration %q", instance.Spec.RetentionDuration)
+		}
+		retentionCutoff = time.Now().Add(-d)
+	}
+
+	var active []string
+	for i, s := range owned {
+		tooOld := !retentionCutoff.IsZero() && s.CreationTimestamp.Time.Before(retentionCutoff)
+		overCount := instance.Spec.RetentionCount > 0 && i >= instance.Spec.RetentionCount
+		if tooOld || overCount {
+			if err := r.client.Delete(ctx, &owned[i]); err != nil && !errors.IsNotFound(err) {
+				return nil, errorUtil.Wrapf(err, "failed to delete redis snapshot %s", s.Name)
+			}
+			continue
+		}
+		active = append(active, s.Name)
+	}
+	return active, nil
+}
+
+func (r *ReconcileRedisSnapshotSchedule) updatePhase(ctx context.Context, instance *integreatlyv1alpha1.RedisSnapshotSchedule, phase croType.StatusPhase, msg croType.StatusMessage) error {
+	instance.Status.Phase = phase
+	instance.Status.Message = msg
+	return r.client.Update(ctx, instance)
+}
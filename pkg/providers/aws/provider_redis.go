@@ -14,7 +14,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
 	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
@@ -24,11 +26,12 @@ import (
 )
 
 const (
-	defaultCacheNodeType     = "cache.t2.micro"
-	defaultEngineVersion     = "3.2.10"
-	defaultDescription       = "A Redis replication group"
-	defaultNumCacheClusters  = 2
-	defaultSnapshotRetention = 30
+	defaultCacheNodeType        = "cache.t2.micro"
+	defaultEngineVersion        = "3.2.10"
+	defaultDescription          = "A Redis replication group"
+	defaultNumCacheClusters     = 2
+	defaultReplicasPerNodeGroup = 1
+	defaultSnapshotRetention    = 30
 )
 
 // AWS Redis Provider implementation for AWS Elasticache
@@ -76,6 +79,17 @@ func (p *AWSRedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (
 		redisConfig.ReplicationGroupId = aws.String(r.Name)
 	}
 
+	// if the CR asks to be restored from a snapshot, resolve it to a name/set of
+	// ARNs ElastiCache understands so createRedisCluster can seed the new group
+	if r.Spec.RestoreFrom != "" {
+		snapshotName, snapshotArns, err := p.resolveRestoreSnapshot(ctx, r)
+		if err != nil {
+			return nil, errorUtil.Wrapf(err, "failed to resolve restore snapshot %s for instance %s", r.Spec.RestoreFrom, r.Name)
+		}
+		redisConfig.SnapshotName = snapshotName
+		redisConfig.SnapshotArns = snapshotArns
+	}
+
 	// create the credentials to be used by the aws resource providers, not to be used by end-user
 	providerCreds, err := p.CredentialManager.ReconcileProviderCredentials(ctx, r.Namespace)
 	if err != nil {
@@ -89,6 +103,36 @@ func (p *AWSRedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (
 	return createRedisCluster(cacheSvc, redisConfig)
 }
 
+// resolveRestoreSnapshot turns r.Spec.RestoreFrom into the ElastiCache snapshot name (same-account restore) or
+// snapshot ARNs (restore from an S3-exported snapshot) expected by CreateReplicationGroupInput. RestoreFrom may
+// either name a RedisSnapshot CR in the same namespace or a raw ElastiCache snapshot name/ARN
+func (p *AWSRedisProvider) resolveRestoreSnapshot(ctx context.Context, r *v1alpha1.Redis) (*string, []*string, error) {
+	// an ARN names an S3-exported snapshot, passed on as SnapshotArns
+	if len(r.Spec.RestoreFrom) > 4 && r.Spec.RestoreFrom[:4] == "arn:" {
+		return nil, []*string{aws.String(r.Spec.RestoreFrom)}, nil
+	}
+
+	snapshotCr := &v1alpha1.RedisSnapshot{}
+	err := p.Client.Get(ctx, client.ObjectKey{Name: r.Spec.RestoreFrom, Namespace: r.Namespace}, snapshotCr)
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			return nil, nil, errorUtil.Wrapf(err, "failed to get redis snapshot cr %s", r.Spec.RestoreFrom)
+		}
+		// not a RedisSnapshot CR, treat RestoreFrom as a raw ElastiCache snapshot name
+		return aws.String(r.Spec.RestoreFrom), nil, nil
+	}
+
+	if snapshotCr.Status.Phase != croType.PhaseComplete {
+		return nil, nil, errorUtil.Errorf("redis snapshot %s is not complete, current phase %s", snapshotCr.Name, snapshotCr.Status.Phase)
+	}
+
+	snapshotName, err := BuildTimestampedInfraNameFromObjectCreation(ctx, p.Client, snapshotCr.ObjectMeta, DefaultAwsIdentifierLength)
+	if err != nil {
+		return nil, nil, errorUtil.Wrapf(err, "failed to build snapshot name for redis snapshot cr %s", snapshotCr.Name)
+	}
+	return aws.String(snapshotName), nil, nil
+}
+
 func createCacheService(stratCfg *StrategyConfig, providerCreds *AWSCredentials) elasticacheiface.ElastiCacheAPI {
 	sess := session.Must(session.NewSession(&aws.Config{
 		Region:      aws.String(stratCfg.Region),
@@ -117,10 +161,14 @@ func createRedisCluster(cacheSvc elasticacheiface.ElastiCacheAPI, redisConfig *e
 	if foundCache != nil {
 		if *foundCache.Status == "available" {
 			logrus.Info("found existing redis cluster")
-			primaryEndpoint := foundCache.NodeGroups[0].PrimaryEndpoint
+			if err := reshardIfNeeded(cacheSvc, foundCache, redisConfig); err != nil {
+				return nil, errorUtil.Wrapf(err, "failed to reshard redis cluster %s", *redisConfig.ReplicationGroupId)
+			}
+			uri, port := clusterEndpoint(foundCache)
 			return &providers.RedisCluster{DeploymentDetails: &providers.RedisDeploymentDetails{
-				URI:  *primaryEndpoint.Address,
-				Port: *primaryEndpoint.Port,
+				URI:    uri,
+				Port:   port,
+				Shards: shardStatus(foundCache),
 			}}, nil
 		}
 		return nil, nil
@@ -137,9 +185,23 @@ func createRedisCluster(cacheSvc elasticacheiface.ElastiCacheAPI, redisConfig *e
 		CacheNodeType:               redisConfig.CacheNodeType,
 		EngineVersion:               redisConfig.EngineVersion,
 		ReplicationGroupDescription: redisConfig.ReplicationGroupDescription,
-		NumCacheClusters:            redisConfig.NumCacheClusters,
 		SnapshotRetentionLimit:      redisConfig.SnapshotRetentionLimit,
 	}
+	// a replication group is either classic mode (NumCacheClusters, a single node group) or cluster mode
+	// enabled (NumNodeGroups shards, each with ReplicasPerNodeGroup replicas) - never both
+	if redisConfig.NumNodeGroups != nil {
+		input.NumNodeGroups = redisConfig.NumNodeGroups
+		input.ReplicasPerNodeGroup = redisConfig.ReplicasPerNodeGroup
+	} else {
+		input.NumCacheClusters = redisConfig.NumCacheClusters
+	}
+	// when restoring from a snapshot, ElastiCache accepts either the name of an existing
+	// snapshot in this account, or a set of S3 ARNs for an exported snapshot, but not both
+	if redisConfig.SnapshotName != nil {
+		input.SnapshotName = redisConfig.SnapshotName
+	} else if len(redisConfig.SnapshotArns) > 0 {
+		input.SnapshotArns = redisConfig.SnapshotArns
+	}
 	_, err = cacheSvc.CreateReplicationGroup(input)
 	if err != nil {
 		return nil, err
@@ -148,8 +210,73 @@ func createRedisCluster(cacheSvc elasticacheiface.ElastiCacheAPI, redisConfig *e
 	return nil, nil
 }
 
+// clusterEndpoint returns the address clients should connect to: the configuration endpoint when the replication
+// group reports one (cluster mode enabled), or the first node group's primary endpoint otherwise
+func clusterEndpoint(rg *elasticache.ReplicationGroup) (string, int64) {
+	if rg.ConfigurationEndpoint != nil {
+		return *rg.ConfigurationEndpoint.Address, *rg.ConfigurationEndpoint.Port
+	}
+	primaryEndpoint := rg.NodeGroups[0].PrimaryEndpoint
+	return *primaryEndpoint.Address, *primaryEndpoint.Port
+}
+
+// shardStatus builds a per-node-group status summary so the caller can surface shard health on the Redis CR,
+// rather than silently dropping node groups 1..N as before
+func shardStatus(rg *elasticache.ReplicationGroup) []providers.RedisShardStatus {
+	shards := make([]providers.RedisShardStatus, 0, len(rg.NodeGroups))
+	for _, ng := range rg.NodeGroups {
+		shards = append(shards, providers.RedisShardStatus{
+			NodeGroupID: *ng.NodeGroupId,
+			Status:      *ng.Status,
+		})
+	}
+	return shards
+}
+
+// reshardIfNeeded invokes an online shard reconfiguration when the strategy config's NumNodeGroups no longer
+// matches the live replication group, e.g. after an operator edits the strategy config to scale shards up or down
+func reshardIfNeeded(cacheSvc elasticacheiface.ElastiCacheAPI, foundCache *elasticache.ReplicationGroup, redisConfig *elasticache.CreateReplicationGroupInput) error {
+	if redisConfig.NumNodeGroups == nil {
+		return nil
+	}
+	desired := *redisConfig.NumNodeGroups
+	current := int64(len(foundCache.NodeGroups))
+	if desired == current {
+		return nil
+	}
+	logrus.Infof("reshard required for %s: %d -> %d node groups", *foundCache.ReplicationGroupId, current, desired)
+
+	input := &elasticache.ModifyReplicationGroupShardConfigurationInput{
+		ReplicationGroupId: foundCache.ReplicationGroupId,
+		NodeGroupCount:     aws.Int64(desired),
+		ApplyImmediately:   aws.Bool(true),
+	}
+	if desired < current {
+		// AWS rejects a scale-down ModifyReplicationGroupShardConfiguration call unless told which node groups
+		// to remove; drop the highest-numbered ones, so NodeGroups[0] is always kept
+		for _, ng := range foundCache.NodeGroups[desired:] {
+			input.NodeGroupsToRemove = append(input.NodeGroupsToRemove, ng.NodeGroupId)
+		}
+	}
+
+	_, err := cacheSvc.ModifyReplicationGroupShardConfiguration(input)
+	return err
+}
+
 // DeleteStorage Delete elasticache replication group
 func (p *AWSRedisProvider) DeleteRedis(ctx context.Context, r *v1alpha1.Redis) error {
+	// the destroy annotation means the aws account/credentials/replication group may already be gone;
+	// skip straight to removing the finalizer rather than wedging the CR on a DeleteReplicationGroup
+	// call that can never succeed
+	if resources.IsDestroySkipRemoteDeleteSet(r) {
+		p.Logger.Infof("destroy annotation set on %s, skipping aws deletion", r.Name)
+		resources.RemoveFinalizer(&r.ObjectMeta, DefaultFinalizer)
+		if err := p.Client.Update(ctx, r); err != nil {
+			return errorUtil.Wrapf(err, "failed to update instance as part of finalizer reconcile")
+		}
+		return nil
+	}
+
 	// resolve redis information for redis created by provider
 	redisConfig, stratCfg, err := p.getRedisConfig(ctx, r)
 	if err != nil {
@@ -262,7 +389,13 @@ func verifyRedisConfig(redisConfig *elasticache.CreateReplicationGroupInput) {
 	if redisConfig.EngineVersion == nil {
 		redisConfig.EngineVersion = aws.String(defaultEngineVersion)
 	}
-	if redisConfig.NumCacheClusters == nil {
+	// cluster mode enabled (NumNodeGroups set) and classic mode (NumCacheClusters) are mutually
+	// exclusive, so only default one depending on which the strategy config opted into
+	if redisConfig.NumNodeGroups != nil {
+		if redisConfig.ReplicasPerNodeGroup == nil {
+			redisConfig.ReplicasPerNodeGroup = aws.Int64(defaultReplicasPerNodeGroup)
+		}
+	} else if redisConfig.NumCacheClusters == nil {
 		redisConfig.NumCacheClusters = aws.Int64(defaultNumCacheClusters)
 	}
 	if redisConfig.SnapshotRetentionLimit == nil {
@@ -0,0 +1,195 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeElastiCacheClient stubs only the ElastiCache calls deleteRedisCluster makes; embedding the interface
+// satisfies the rest of elasticacheiface.ElastiCacheAPI without having to implement it in full
+type fakeElastiCacheClient struct {
+	elasticacheiface.ElastiCacheAPI
+	describeReplicationGroupsFn func(*elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error)
+	deleteReplicationGroupFn    func(*elasticache.DeleteReplicationGroupInput) (*elasticache.DeleteReplicationGroupOutput, error)
+}
+
+func (f *fakeElastiCacheClient) DescribeReplicationGroups(in *elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error) {
+	return f.describeReplicationGroupsFn(in)
+}
+
+func (f *fakeElastiCacheClient) DeleteReplicationGroup(in *elasticache.DeleteReplicationGroupInput) (*elasticache.DeleteReplicationGroupOutput, error) {
+	return f.deleteReplicationGroupFn(in)
+}
+
+// succeedingConfigManager always returns a fixed strategy config with an empty redis strategy, so tests can reach
+// the CredentialManager path of DeleteRedis without a real cloud-resources-aws-strategies configmap
+type succeedingConfigManager struct {
+	ConfigManager
+}
+
+func (succeedingConfigManager) ReadStorageStrategy(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+	return &StrategyConfig{Region: "eu-west-1", RawStrategy: []byte("{}")}, nil
+}
+
+// failingCredentialManager always fails ReconcileProviderCredentials, standing in for invalid/unreachable
+// provider credentials. Embedding the interface means it satisfies CredentialManager without needing its
+// full method set
+type failingCredentialManager struct {
+	CredentialManager
+}
+
+func (failingCredentialManager) ReconcileProviderCredentials(ctx context.Context, ns string) (*AWSCredentials, error) {
+	return nil, awserr.New("InvalidClientTokenId", "the security token included in the request is invalid", nil)
+}
+
+// explodingCredentialManager fails the test if it's ever called - used to prove the destroy-annotation path
+// never reaches out to AWS at all
+type explodingCredentialManager struct {
+	CredentialManager
+	t *testing.T
+}
+
+func (e explodingCredentialManager) ReconcileProviderCredentials(ctx context.Context, ns string) (*AWSCredentials, error) {
+	e.t.Fatal("ReconcileProviderCredentials should not be called when the destroy annotation is set")
+	return nil, nil
+}
+
+func testRedisCr(destroyAnnotation bool) *v1alpha1.Redis {
+	r := &v1alpha1.Redis{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-redis",
+			Namespace:  "test-ns",
+			Finalizers: []string{DefaultFinalizer},
+		},
+	}
+	if destroyAnnotation {
+		r.SetAnnotations(map[string]string{resources.DestroyAnnotation: "true"})
+	}
+	return r
+}
+
+func fakeRedisClient(objs ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return fake.NewFakeClientWithScheme(scheme, objs...)
+}
+
+func hasFinalizer(r *v1alpha1.Redis, finalizer string) bool {
+	for _, f := range r.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAWSRedisProvider_DeleteRedis_DestroyAnnotationSkipsRemoteDelete(t *testing.T) {
+	r := testRedisCr(true)
+	p := &AWSRedisProvider{
+		Client:            fakeRedisClient(r),
+		Logger:            logrus.NewEntry(logrus.New()),
+		CredentialManager: explodingCredentialManager{t: t},
+	}
+
+	if err := p.DeleteRedis(context.TODO(), r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasFinalizer(r, DefaultFinalizer) {
+		t.Error("expected finalizer to be removed when destroy annotation is set")
+	}
+}
+
+func TestAWSRedisProvider_DeleteRedis_CredentialsInvalid(t *testing.T) {
+	for _, destroyAnnotation := range []bool{true, false} {
+		r := testRedisCr(destroyAnnotation)
+		p := &AWSRedisProvider{
+			Client:            fakeRedisClient(r),
+			Logger:            logrus.NewEntry(logrus.New()),
+			ConfigManager:     succeedingConfigManager{},
+			CredentialManager: failingCredentialManager{},
+		}
+
+		err := p.DeleteRedis(context.TODO(), r)
+		if destroyAnnotation {
+			// the destroy annotation short-circuits before credentials are ever reconciled
+			if err != nil {
+				t.Errorf("destroy annotation set: unexpected error: %v", err)
+			}
+			if hasFinalizer(r, DefaultFinalizer) {
+				t.Error("destroy annotation set: expected finalizer to be removed")
+			}
+			continue
+		}
+		if err == nil {
+			t.Error("destroy annotation unset: expected error when credentials are invalid")
+		}
+		if !hasFinalizer(r, DefaultFinalizer) {
+			t.Error("destroy annotation unset: finalizer should remain while credentials are invalid")
+		}
+	}
+}
+
+func TestAWSRedisProvider_deleteRedisCluster_ClusterMissing(t *testing.T) {
+	r := testRedisCr(false)
+	p := &AWSRedisProvider{
+		Client: fakeRedisClient(r),
+		Logger: logrus.NewEntry(logrus.New()),
+	}
+	cacheSvc := &fakeElastiCacheClient{
+		describeReplicationGroupsFn: func(*elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error) {
+			return &elasticache.DescribeReplicationGroupsOutput{}, nil
+		},
+	}
+	redisConfig := &elasticache.CreateReplicationGroupInput{ReplicationGroupId: aws.String(r.Name)}
+
+	if err := p.deleteRedisCluster(cacheSvc, redisConfig, context.TODO(), r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasFinalizer(r, DefaultFinalizer) {
+		t.Error("expected finalizer to be removed once the replication group is gone")
+	}
+}
+
+func TestAWSRedisProvider_deleteRedisCluster_AccessDenied(t *testing.T) {
+	r := testRedisCr(false)
+	p := &AWSRedisProvider{
+		Client: fakeRedisClient(r),
+		Logger: logrus.NewEntry(logrus.New()),
+	}
+	cacheSvc := &fakeElastiCacheClient{
+		describeReplicationGroupsFn: func(*elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error) {
+			return &elasticache.DescribeReplicationGroupsOutput{
+				ReplicationGroups: []*elasticache.ReplicationGroup{
+					{ReplicationGroupId: aws.String(r.Name), Status: aws.String("available")},
+				},
+			}, nil
+		},
+		deleteReplicationGroupFn: func(*elasticache.DeleteReplicationGroupInput) (*elasticache.DeleteReplicationGroupOutput, error) {
+			return nil, awserr.New("AccessDenied", "user is not authorized to perform elasticache:DeleteReplicationGroup", nil)
+		},
+	}
+	redisConfig := &elasticache.CreateReplicationGroupInput{ReplicationGroupId: aws.String(r.Name)}
+
+	err := p.deleteRedisCluster(cacheSvc, redisConfig, context.TODO(), r)
+	if err == nil {
+		t.Fatal("expected an error when aws denies the delete")
+	}
+	if !hasFinalizer(r, DefaultFinalizer) {
+		t.Error("finalizer should remain when the remote delete fails")
+	}
+}
@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/sirupsen/logrus"
+
+	errorUtil "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	defaultRedisEventsSNSTopic = "cloud-resource-operator-redis-events"
+	defaultRedisEventsSQSQueue = "cloud-resource-operator-redis-events"
+	redisEventsQueueArnAttr    = "QueueArn"
+
+	// minReceiveBackoff/maxReceiveBackoff bound the delay between ReceiveMessage retries after an error, so a
+	// persistent auth/permissions problem backs off instead of hammering the SQS API in a tight loop
+	minReceiveBackoff = time.Second
+	maxReceiveBackoff = time.Minute
+)
+
+// RedisEventBridge subscribes an SQS queue to an SNS topic carrying ElastiCache event notifications, so Redis and
+// RedisSnapshot reconciles can be triggered as soon as AWS reports a state change instead of waiting on the next
+// polling requeue. It is optional: callers that don't construct one keep the existing RequeueAfter-based polling
+type RedisEventBridge struct {
+	logger   *logrus.Entry
+	snsSvc   snsiface.SNSAPI
+	sqsSvc   sqsiface.SQSAPI
+	TopicArn string
+	QueueURL string
+}
+
+// NewRedisEventBridge provisions (or reuses, since CreateTopic/CreateQueue are idempotent on name) the SNS topic and
+// SQS queue used to carry ElastiCache events, and subscribes the queue to the topic
+func NewRedisEventBridge(sess *session.Session, logger *logrus.Entry) (*RedisEventBridge, error) {
+	snsSvc := sns.New(sess)
+	sqsSvc := sqs.New(sess)
+
+	topicOutput, err := snsSvc.CreateTopic(&sns.CreateTopicInput{Name: aws.String(defaultRedisEventsSNSTopic)})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to create redis events sns topic")
+	}
+
+	queueOutput, err := sqsSvc.CreateQueue(&sqs.CreateQueueInput{QueueName: aws.String(defaultRedisEventsSQSQueue)})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to create redis events sqs queue")
+	}
+
+	attrOutput, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       queueOutput.QueueUrl,
+		AttributeNames: []*string{aws.String(redisEventsQueueArnAttr)},
+	})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get redis events queue arn")
+	}
+	queueArn := aws.StringValue(attrOutput.Attributes[redisEventsQueueArnAttr])
+
+	if _, err := snsSvc.Subscribe(&sns.SubscribeInput{
+		TopicArn: topicOutput.TopicArn,
+		Protocol: aws.String("sqs"),
+		Endpoint: aws.String(queueArn),
+	}); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to subscribe redis events queue to topic")
+	}
+
+	return &RedisEventBridge{
+		logger:   logger.WithFields(logrus.Fields{"provider": "aws_redis_eventbridge"}),
+		snsSvc:   snsSvc,
+		sqsSvc:   sqsSvc,
+		TopicArn: aws.StringValue(topicOutput.TopicArn),
+		QueueURL: aws.StringValue(queueOutput.QueueUrl),
+	}, nil
+}
+
+// snsNotification is the envelope SNS wraps every message in before handing it to SQS
+type snsNotification struct {
+	Message string `json:"Message"`
+}
+
+// elastiCacheEvent is the subset of an ElastiCache event notification payload this bridge cares about
+type elastiCacheEvent struct {
+	SourceIdentifier string `json:"SourceId"`
+}
+
+// Start polls the queue until ctx is cancelled, resolving each event's SourceIdentifier back to the owning
+// Redis/RedisSnapshot CR via resolve, and pushing a GenericEvent onto events for every match found
+func (b *RedisEventBridge) Start(ctx context.Context, resolve func(ctx context.Context, sourceIdentifier string) ([]event.GenericEvent, error), events chan event.GenericEvent) {
+	backoff := minReceiveBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		output, err := b.sqsSvc.ReceiveMessage(&sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(b.QueueURL),
+			WaitTimeSeconds:     aws.Int64(20),
+			MaxNumberOfMessages: aws.Int64(10),
+		})
+		if err != nil {
+			b.logger.Errorf("failed to receive redis events from sqs: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxReceiveBackoff {
+				backoff = maxReceiveBackoff
+			}
+			continue
+		}
+		backoff = minReceiveBackoff
+
+		for _, msg := range output.Messages {
+			var notification snsNotification
+			if err := json.Unmarshal([]byte(aws.StringValue(msg.Body)), &notification); err != nil {
+				b.logger.Errorf("failed to unmarshal sns notification: %v", err)
+				continue
+			}
+			var ecEvent elastiCacheEvent
+			if err := json.Unmarshal([]byte(notification.Message), &ecEvent); err != nil {
+				b.logger.Errorf("failed to unmarshal elasticache event: %v", err)
+				continue
+			}
+
+			matches, err := resolve(ctx, ecEvent.SourceIdentifier)
+			if err != nil {
+				b.logger.Errorf("failed to resolve elasticache event source %s: %v", ecEvent.SourceIdentifier, err)
+				continue
+			}
+			for _, evt := range matches {
+				events <- evt
+			}
+
+			if _, err := b.sqsSvc.DeleteMessage(&sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(b.QueueURL),
+				ReceiptHandle: msg.ReceiptHandle,
+			}); err != nil {
+				b.logger.Errorf("failed to delete redis event message: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	"github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+
+	errorUtil "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	defaultRedisImage    = "redis:5.0-alpine"
+	defaultRedisPort     = 6379
+	defaultFollowerCount = 2
+	authSecretKey        = "password"
+	// DefaultFinalizer is applied to Redis CRs provisioned by this provider, mirroring the aws provider
+	DefaultFinalizer = "kubernetes.redis.cloud-resources.integreatly.org/finalizer"
+)
+
+// KubernetesRedisProvider provisions Redis in-cluster as a leader/follower StatefulSet, for use in environments
+// without access to an AWS account
+type KubernetesRedisProvider struct {
+	Client client.Client
+	Logger *logrus.Entry
+}
+
+func NewKubernetesRedisProvider(client client.Client, logger *logrus.Entry) *KubernetesRedisProvider {
+	return &KubernetesRedisProvider{
+		Client: client,
+		Logger: logger.WithFields(logrus.Fields{"provider": "kubernetes_redis"}),
+	}
+}
+
+func (p *KubernetesRedisProvider) GetName() string {
+	return providers.KubernetesDeploymentStrategy
+}
+
+func (p *KubernetesRedisProvider) SupportsStrategy(d string) bool {
+	return d == providers.KubernetesDeploymentStrategy
+}
+
+// CreateRedis provisions a Secret (auth token), a headless Service, and a leader/follower StatefulSet for r, and
+// reports the cluster as ready once the StatefulSet has all replicas ready
+func (p *KubernetesRedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*providers.RedisCluster, error) {
+	if r.GetDeletionTimestamp() == nil {
+		resources.AddFinalizer(&r.ObjectMeta, DefaultFinalizer)
+		if err := p.Client.Update(ctx, r); err != nil {
+			return nil, errorUtil.Wrapf(err, "failed to add finalizer to instance")
+		}
+	}
+
+	secret, err := p.reconcileAuthSecret(ctx, r)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to reconcile redis auth secret")
+	}
+
+	svc, err := p.reconcileService(ctx, r)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to reconcile redis headless service")
+	}
+
+	sts, err := p.reconcileStatefulSet(ctx, r, secret.Name)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to reconcile redis statefulset")
+	}
+
+	if sts.Status.ReadyReplicas != *sts.Spec.Replicas {
+		p.Logger.Infof("waiting for redis statefulset %s to become ready, %d/%d replicas ready", sts.Name, sts.Status.ReadyReplicas, *sts.Spec.Replicas)
+		return nil, nil
+	}
+
+	return &providers.RedisCluster{DeploymentDetails: &providers.RedisDeploymentDetails{
+		URI:  fmt.Sprintf("%s-0.%s.%s.svc", sts.Name, svc.Name, r.Namespace),
+		Port: defaultRedisPort,
+	}}, nil
+}
+
+// DeleteRedis tears down the StatefulSet, Service and Secret created by CreateRedis, then removes the finalizer
+func (p *KubernetesRedisProvider) DeleteRedis(ctx context.Context, r *v1alpha1.Redis) error {
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: r.Name, Namespace: r.Namespace}}
+	if err := p.Client.Delete(ctx, sts); err != nil && !k8serr.IsNotFound(err) {
+		return errorUtil.Wrapf(err, "failed to delete redis statefulset %s", r.Name)
+	}
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: r.Name, Namespace: r.Namespace}}
+	if err := p.Client.Delete(ctx, svc); err != nil && !k8serr.IsNotFound(err) {
+		return errorUtil.Wrapf(err, "failed to delete redis service %s", r.Name)
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: authSecretName(r), Namespace: r.Namespace}}
+	if err := p.Client.Delete(ctx, secret); err != nil && !k8serr.IsNotFound(err) {
+		return errorUtil.Wrapf(err, "failed to delete redis auth secret %s", authSecretName(r))
+	}
+
+	resources.RemoveFinalizer(&r.ObjectMeta, DefaultFinalizer)
+	if err := p.Client.Update(ctx, r); err != nil {
+		return errorUtil.Wrapf(err, "failed to update instance as part of finalizer reconcile")
+	}
+	return nil
+}
+
+func authSecretName(r *v1alpha1.Redis) string {
+	return r.Name + "-auth"
+}
+
+func (p *KubernetesRedisProvider) reconcileAuthSecret(ctx context.Context, r *v1alpha1.Redis) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := p.Client.Get(ctx, types.NamespacedName{Name: authSecretName(r), Namespace: r.Namespace}, secret)
+	if err == nil {
+		return secret, nil
+	}
+	if !k8serr.IsNotFound(err) {
+		return nil, err
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to generate redis auth token")
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: authSecretName(r), Namespace: r.Namespace},
+		StringData: map[string]string{authSecretKey: base64.RawURLEncoding.EncodeToString(token)},
+	}
+	if err := p.Client.Create(ctx, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (p *KubernetesRedisProvider) reconcileService(ctx context.Context, r *v1alpha1.Redis) (*corev1.Service, error) {
+	svc := &corev1.Service{}
+	err := p.Client.Get(ctx, types.NamespacedName{Name: r.Name, Namespace: r.Namespace}, svc)
+	if err == nil {
+		return svc, nil
+	}
+	if !k8serr.IsNotFound(err) {
+		return nil, err
+	}
+
+	svc = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: r.Name, Namespace: r.Namespace},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  redisLabels(r),
+			Ports: []corev1.ServicePort{
+				{Name: "redis", Port: defaultRedisPort, TargetPort: intstr.FromInt(defaultRedisPort)},
+			},
+		},
+	}
+	if err := p.Client.Create(ctx, svc); err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+func (p *KubernetesRedisProvider) reconcileStatefulSet(ctx context.Context, r *v1alpha1.Redis, secretName string) (*appsv1.StatefulSet, error) {
+	sts := &appsv1.StatefulSet{}
+	err := p.Client.Get(ctx, types.NamespacedName{Name: r.Name, Namespace: r.Namespace}, sts)
+	if err == nil {
+		return sts, nil
+	}
+	if !k8serr.IsNotFound(err) {
+		return nil, err
+	}
+
+	replicas := int32(1 + defaultFollowerCount)
+	sts = &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.Name,
+			Namespace: r.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(r, v1alpha1.SchemeGroupVersion.WithKind("Redis")),
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: r.Name,
+			Replicas:    &replicas,
+			Selector:    &metav1.LabelSelector{MatchLabels: redisLabels(r)},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: redisLabels(r)},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:    "redis",
+							Image:   defaultRedisImage,
+							Command: []string{"/bin/sh", "-c"},
+							// upstream redis's entrypoint doesn't read REDIS_PASSWORD (that's a Bitnami-image
+							// convention) and won't REPLICAOF a leader on its own, so the leader/follower role and
+							// auth enforcement are driven explicitly here instead: pod ordinal 0 is the leader,
+							// every other pod replicates from it, and --requirepass/--masterauth are always passed
+							Args: []string{fmt.Sprintf(
+								`ordinal=$(hostname | sed 's/.*-//')
+if [ "$ordinal" = "0" ]; then
+  exec redis-server --requirepass "$REDIS_PASSWORD" --masterauth "$REDIS_PASSWORD"
+fi
+exec redis-server --requirepass "$REDIS_PASSWORD" --masterauth "$REDIS_PASSWORD" --replicaof %s-0.%s.%s.svc %d`,
+								r.Name, r.Name, r.Namespace, defaultRedisPort)},
+							Ports: []corev1.ContainerPort{{ContainerPort: defaultRedisPort}},
+							Env: []corev1.EnvVar{
+								{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+										Key:                  authSecretKey,
+									},
+								}},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "data", MountPath: "/data"},
+							},
+						},
+					},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := p.Client.Create(ctx, sts); err != nil {
+		return nil, err
+	}
+	return sts, nil
+}
+
+func redisLabels(r *v1alpha1.Redis) map[string]string {
+	return map[string]string{"redis-cr": r.Name}
+}
+
+// AddStatefulSetWatch registers a watch on the StatefulSets this provider creates, enqueueing the owning Redis CR so
+// scaling and readiness changes are reconciled promptly instead of waiting on the Redis controller's poll interval.
+// It is intended to be called from the main Redis controller's add() alongside its other secondary-resource watches.
+func AddStatefulSetWatch(c controller.Controller) error {
+	return c.Watch(&source.Kind{Type: &appsv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &v1alpha1.Redis{},
+	})
+}
@@ -0,0 +1,149 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	errorUtil "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const snapshotJobImage = defaultRedisImage
+
+// CreateRedisSnapshot is the in-cluster equivalent of the aws provider's elasticache snapshot creation: it runs a
+// BGSAVE against the leader pod and copies the resulting RDB file from the "data" PVC to <snapshot name>.rdb on a
+// snapshots PVC shared by every snapshot of redisCr, via a short-lived Job. It is polled by ReconcileRedisSnapshot in the same way the aws provider's
+// DescribeSnapshots call is, returning the snapshot's current phase
+func CreateRedisSnapshot(ctx context.Context, c client.Client, logger *logrus.Entry, redisCr *v1alpha1.Redis, snapshotCr *v1alpha1.RedisSnapshot) (croType.StatusPhase, error) {
+	jobName := snapshotCr.Name + "-bgsave"
+	job := &batchv1.Job{}
+	err := c.Get(ctx, types.NamespacedName{Name: jobName, Namespace: snapshotCr.Namespace}, job)
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			return croType.PhaseFailed, errorUtil.Wrapf(err, "failed to get snapshot job %s", jobName)
+		}
+
+		if err := reconcileSnapshotsPVC(ctx, c, redisCr); err != nil {
+			return croType.PhaseFailed, errorUtil.Wrap(err, "failed to reconcile snapshots pvc")
+		}
+
+		// pin the job pod to whatever node the leader pod is already scheduled on, since the "data" PVC it
+		// mounts read-only alongside the leader is ReadWriteOnce and most CSI drivers only permit that from a
+		// single node at a time
+		leaderPod := &corev1.Pod{}
+		if err := c.Get(ctx, types.NamespacedName{Name: redisCr.Name + "-0", Namespace: redisCr.Namespace}, leaderPod); err != nil {
+			return croType.PhaseFailed, errorUtil.Wrap(err, "failed to get leader pod for snapshot job node affinity")
+		}
+
+		logger.Infof("creating bgsave snapshot job %s", jobName)
+		job = bgsaveJob(jobName, redisCr, snapshotCr, leaderPod.Spec.NodeName)
+		if err := c.Create(ctx, job); err != nil {
+			return croType.PhaseFailed, errorUtil.Wrapf(err, "failed to create snapshot job %s", jobName)
+		}
+		return croType.PhaseInProgress, nil
+	}
+
+	if job.Status.Succeeded > 0 {
+		return croType.PhaseComplete, nil
+	}
+	if job.Status.Failed > 0 {
+		return croType.PhaseFailed, errorUtil.Errorf("snapshot job %s failed", jobName)
+	}
+	return croType.PhaseInProgress, nil
+}
+
+// DeleteRedisSnapshot removes the Job created for snapshotCr. The copied <snapshot name>.rdb file on the shared
+// snapshots PVC is not removed
+func DeleteRedisSnapshot(ctx context.Context, c client.Client, snapshotCr *v1alpha1.RedisSnapshot) error {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: snapshotCr.Name + "-bgsave", Namespace: snapshotCr.Namespace}}
+	propagation := metav1.DeletePropagationBackground
+	if err := c.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !k8serr.IsNotFound(err) {
+		return errorUtil.Wrapf(err, "failed to delete snapshot job for %s", snapshotCr.Name)
+	}
+	return nil
+}
+
+// snapshotsPVCName returns the name of the PVC the bgsave job copies snapshot RDB files into, shared across every
+// snapshot taken of redisCr
+func snapshotsPVCName(redisCr *v1alpha1.Redis) string {
+	return redisCr.Name + "-snapshots"
+}
+
+// reconcileSnapshotsPVC creates the shared snapshots PVC for redisCr if it doesn't already exist
+func reconcileSnapshotsPVC(ctx context.Context, c client.Client, redisCr *v1alpha1.Redis) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := c.Get(ctx, types.NamespacedName{Name: snapshotsPVCName(redisCr), Namespace: redisCr.Namespace}, pvc)
+	if err == nil {
+		return nil
+	}
+	if !k8serr.IsNotFound(err) {
+		return err
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: snapshotsPVCName(redisCr), Namespace: redisCr.Namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	}
+	return c.Create(ctx, pvc)
+}
+
+func bgsaveJob(name string, redisCr *v1alpha1.Redis, snapshotCr *v1alpha1.RedisSnapshot, leaderNodeName string) *batchv1.Job {
+	leaderPod := redisCr.Name + "-0"
+	// the leader always has --requirepass set (see reconcileStatefulSet), so BGSAVE must authenticate the
+	// same way the leader/follower containers do: via the REDIS_PASSWORD env var sourced from the auth secret
+	script := fmt.Sprintf(`redis-cli -h %s -a "$REDIS_PASSWORD" BGSAVE && sleep 5 && cp /data/dump.rdb /snapshots/%s.rdb`, leaderPod, snapshotCr.Name)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: snapshotCr.Namespace},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					NodeName:      leaderNodeName,
+					Containers: []corev1.Container{
+						{
+							Name:    "bgsave",
+							Image:   snapshotJobImage,
+							Command: []string{"/bin/sh", "-c", script},
+							Env: []corev1.EnvVar{
+								{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: authSecretName(redisCr)},
+										Key:                  authSecretKey,
+									},
+								}},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "data", MountPath: "/data", ReadOnly: true},
+								{Name: "snapshots", MountPath: "/snapshots"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: "data", VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-" + leaderPod, ReadOnly: true},
+						}},
+						{Name: "snapshots", VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: snapshotsPVCName(redisCr)},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+// Package providers declares the shared types and deployment-strategy constants implemented by the
+// provider-specific packages under pkg/providers/aws and pkg/providers/kubernetes
+package providers
+
+// ResourceType identifies a kind of managed resource (redis, postgres, etc.) when looking up its
+// strategy config, independently of which deployment strategy ends up provisioning it
+type ResourceType string
+
+const (
+	// RedisResourceType is the ResourceType used to look up Redis strategy config
+	RedisResourceType ResourceType = "redis"
+)
+
+const (
+	// AWSDeploymentStrategy provisions resources against a real cloud provider account
+	AWSDeploymentStrategy = "aws"
+	// KubernetesDeploymentStrategy provisions resources in-cluster, for use in environments without
+	// access to a cloud provider account
+	KubernetesDeploymentStrategy = "kubernetes"
+)
+
+// RedisCluster is returned by a RedisProvider once a Redis instance has been created
+type RedisCluster struct {
+	DeploymentDetails *RedisDeploymentDetails
+}
+
+// RedisDeploymentDetails carries the connection details for a provisioned Redis instance
+type RedisDeploymentDetails struct {
+	URI  string
+	Port int64
+	// Shards reports the per-node-group status of the replication group, one entry per node group; a
+	// classic (single node group) one reports a single entry
+	Shards []RedisShardStatus
+}
+
+// RedisShardStatus reports the health of a single ElastiCache node group (shard)
+type RedisShardStatus struct {
+	NodeGroupID string
+	Status      string
+}
@@ -0,0 +1,16 @@
+package resources
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DestroyAnnotation mirrors Rook's "yes-really-destroy-data" cleanup policy: when present and set to "true" on a
+// Redis or RedisSnapshot CR, providers must skip calling out to the remote backend on delete and simply remove
+// their finalizer, since the remote resource is assumed to already be gone (account torn down, credentials
+// revoked, or the resource removed out-of-band)
+const DestroyAnnotation = "cloud-resources.integreatly.org/destroy"
+
+// IsDestroySkipRemoteDeleteSet reports whether meta carries DestroyAnnotation set to "true"
+func IsDestroySkipRemoteDeleteSet(meta metav1.Object) bool {
+	return meta.GetAnnotations()[DestroyAnnotation] == "true"
+}